@@ -0,0 +1,156 @@
+package vsm
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BulkOptions configures a BulkIndexer.
+type BulkOptions struct {
+	// MaxDocs is the number of documents a worker buffers before
+	// flushing them into the VSM.
+	MaxDocs int
+	// FlushInterval is the longest a buffered document waits before
+	// being flushed, regardless of MaxDocs.
+	FlushInterval time.Duration
+	// Workers is the number of goroutines concurrently indexing
+	// documents.
+	Workers int
+}
+
+// IndexError reports a Document that failed to index, and why.
+type IndexError struct {
+	Doc Document
+	Err error
+}
+
+// BulkIndexer lets callers push documents into a VSM at a high rate and
+// receive per-document failures on ErrorChannel instead of having one
+// bad document abort the whole batch.
+type BulkIndexer struct {
+	vsm  *VSM
+	opts BulkOptions
+
+	docsCh chan Document
+	errCh  chan IndexError
+
+	// stopping is closed by Stop to tell every worker to flush and
+	// return. It exists so Stop never has to close docsCh itself: doing
+	// that would race with a concurrent Add's send on the same channel
+	// and panic.
+	stopping chan struct{}
+	// stopped is closed once every worker has returned, whether that's
+	// because Stop was called or because the Start ctx was done. Add
+	// selects on it so a caller can't block forever pushing into a pool
+	// that has already shut itself down.
+	stopped chan struct{}
+
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewBulkIndexer creates a BulkIndexer that indexes into v. Zero-valued
+// fields of opts fall back to 1 worker, 1 document per flush and a
+// 1 second flush interval.
+func (v *VSM) NewBulkIndexer(opts BulkOptions) *BulkIndexer {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.MaxDocs <= 0 {
+		opts.MaxDocs = 1
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+
+	return &BulkIndexer{
+		vsm:      v,
+		opts:     opts,
+		docsCh:   make(chan Document),
+		errCh:    make(chan IndexError),
+		stopping: make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// Start launches the worker pool. Call it once, before the first Add.
+// Workers stop flushing and return as soon as ctx is done.
+func (bi *BulkIndexer) Start(ctx context.Context) {
+	for i := 0; i < bi.opts.Workers; i++ {
+		bi.wg.Add(1)
+		go bi.worker(ctx)
+	}
+
+	go func() {
+		bi.wg.Wait()
+		close(bi.stopped)
+	}()
+}
+
+// Add enqueues doc for indexing. It blocks until a worker picks it up,
+// or returns without enqueuing doc if the worker pool has already shut
+// down (Stop was called, or the ctx passed to Start is done).
+func (bi *BulkIndexer) Add(doc Document) {
+	select {
+	case bi.docsCh <- doc:
+	case <-bi.stopped:
+	}
+}
+
+// ErrorChannel returns the channel on which failed documents are
+// reported. It is closed once Stop has flushed every worker.
+func (bi *BulkIndexer) ErrorChannel() <-chan IndexError {
+	return bi.errCh
+}
+
+// Stop tells every worker to flush its buffer, waits for them to do so,
+// and closes ErrorChannel. It is safe to call more than once, but not
+// concurrently with Add: a pending Add may still lose its doc to a
+// worker that's already exiting.
+func (bi *BulkIndexer) Stop() {
+	bi.stopOnce.Do(func() { close(bi.stopping) })
+	bi.wg.Wait()
+	close(bi.errCh)
+}
+
+// worker owns its own buffer of documents, flushing it into the VSM
+// (indexing each one through VSM.indexDoc, the same path Train uses)
+// once MaxDocs is reached or FlushInterval elapses. Buffering per
+// worker keeps contention on the VSM's shared, RWMutex-guarded corpus
+// to the flush itself rather than every single Add.
+func (bi *BulkIndexer) worker(ctx context.Context) {
+	defer bi.wg.Done()
+
+	ticker := time.NewTicker(bi.opts.FlushInterval)
+	defer ticker.Stop()
+
+	buf := make([]Document, 0, bi.opts.MaxDocs)
+
+	flush := func() {
+		for _, doc := range buf {
+			if ie := bi.vsm.indexDoc(doc); ie.Err != nil {
+				bi.errCh <- ie
+			}
+		}
+		buf = buf[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			return
+		case <-bi.stopping:
+			flush()
+			return
+		case doc := <-bi.docsCh:
+			buf = append(buf, doc)
+			if len(buf) >= bi.opts.MaxDocs {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
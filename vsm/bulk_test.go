@@ -0,0 +1,120 @@
+package vsm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// selectiveFailTransformer fails the transform step for sentences
+// matched by fail, and otherwise passes the bytes through unchanged.
+type selectiveFailTransformer struct {
+	fail func(src []byte) bool
+}
+
+func (t *selectiveFailTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	if t.fail(src) {
+		return 0, 0, errors.New("injected transform failure")
+	}
+
+	n := copy(dst, src)
+	return n, n, nil
+}
+
+func (t *selectiveFailTransformer) Reset() {}
+
+func TestBulkIndexer(t *testing.T) {
+	vsm := New(&selectiveFailTransformer{
+		fail: func(src []byte) bool {
+			return bytes.Contains(src, []byte("poison"))
+		},
+	})
+
+	bi := vsm.NewBulkIndexer(BulkOptions{MaxDocs: 2, FlushInterval: 50 * time.Millisecond, Workers: 2})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bi.Start(ctx)
+
+	var gotErrs []IndexError
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for e := range bi.ErrorChannel() {
+			gotErrs = append(gotErrs, e)
+		}
+	}()
+
+	good := docsFixture()[:2]
+	bad := []Document{
+		{Sentence: "This document is poison and should fail.", Class: "bad1"},
+		{Sentence: "Another poison document here.", Class: "bad2"},
+	}
+
+	for _, doc := range good {
+		bi.Add(doc)
+	}
+	for _, doc := range bad {
+		bi.Add(doc)
+	}
+
+	bi.Stop()
+	<-done
+
+	if len(gotErrs) != len(bad) {
+		t.Fatalf("Got %d index errors; want %d.", len(gotErrs), len(bad))
+	}
+
+	for _, e := range gotErrs {
+		if e.Err == nil {
+			t.Errorf("Got nil Err on IndexError for %+v.", e.Doc)
+		}
+	}
+
+	for _, query := range []string{"gold fire", "silver truck"} {
+		t.Run(query, func(t *testing.T) {
+			doc, err := vsm.Search(query)
+			if err != nil {
+				t.Fatalf("Got error while searching for %q: %q.", query, err)
+			}
+
+			if doc == nil {
+				t.Fatalf("Got no document found for query: %q.", query)
+			}
+		})
+	}
+}
+
+// TestBulkIndexerAddAfterContextDone guards against the worker pool
+// shutting down (ctx done, no Stop called yet) while a caller is still
+// pushing documents: Add must return instead of blocking forever on a
+// docsCh nothing is draining anymore.
+func TestBulkIndexerAddAfterContextDone(t *testing.T) {
+	vsm := New(nil)
+
+	bi := vsm.NewBulkIndexer(BulkOptions{MaxDocs: 1, FlushInterval: time.Second, Workers: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bi.Start(ctx)
+	cancel()
+
+	go func() {
+		for range bi.ErrorChannel() {
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bi.Add(Document{Sentence: "should not block", Class: "d1"})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Add blocked after ctx passed to Start was done.")
+	}
+}
@@ -0,0 +1,45 @@
+package vsm
+
+import "context"
+
+// docSource mirrors source.Source structurally. It is declared here,
+// rather than imported from vsm/source, because vsm/source imports this
+// package for the Document type — importing it back would be a cycle.
+// Any source.Source value satisfies this interface already.
+type docSource interface {
+	Documents(ctx context.Context) (<-chan Document, <-chan error)
+}
+
+// TrainFromSource wires src's Document stream into Train, merging src's
+// error channel with Train's TrainResults so a single channel reports
+// everything that went wrong, whether it was reading from src or
+// indexing a Document.
+func (v *VSM) TrainFromSource(ctx context.Context, src docSource) <-chan TrainResult {
+	docsCh, srcErrCh := src.Documents(ctx)
+	trainedCh := v.Train(ctx, docsCh)
+
+	resCh := make(chan TrainResult)
+
+	go func() {
+		defer close(resCh)
+
+		for trainedCh != nil || srcErrCh != nil {
+			select {
+			case res, ok := <-trainedCh:
+				if !ok {
+					trainedCh = nil
+					continue
+				}
+				resCh <- res
+			case err, ok := <-srcErrCh:
+				if !ok {
+					srcErrCh = nil
+					continue
+				}
+				resCh <- TrainResult{Err: err}
+			}
+		}
+	}()
+
+	return resCh
+}
@@ -0,0 +1,128 @@
+package vsm_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fossabot/go-vsm/vsm"
+	"github.com/fossabot/go-vsm/vsm/source"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+)
+
+var fromFile = flag.Bool("fromfile", false, `test from files inside "testdata" dir.`)
+var fileName = flag.String("filename", "training.json", "name of the file that contains the tests.")
+
+type fileTest struct {
+	Docs      []vsm.Document `json:"documents"`
+	Transform *struct {
+		Map *struct {
+			Runes string `json:"runes"`
+			To    string `json:"to"`
+		} `json:"map"`
+	} `json:"transform"`
+	Tests []struct {
+		Query       string   `json:"query"`
+		WantClasses []string `json:"wantClasses"`
+	} `json:"tests"`
+}
+
+// openTestFile reads a file from `name` and returns a file descriptor.
+// The file should be in `testdata` dir, as the `name` will be prefixed
+// with `testdata/`. It the caller's responsability to close the file when needed.
+func openTestFile(name string) (*os.File, error) {
+	file, err := os.Open(fmt.Sprintf("testdata%s%s", string(os.PathSeparator), name))
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func setupTransformer(training fileTest) transform.Transformer {
+	var transf transform.Transformer
+	if training.Transform != nil {
+		var transformers []transform.Transformer
+
+		if m := training.Transform.Map; m != nil {
+			newRune := []rune(m.To)
+			transformers = append(transformers, runes.Map(func(r rune) rune {
+				if strings.ContainsRune(m.Runes, r) && len(newRune) > 0 {
+					return newRune[0]
+				}
+				return r
+			}))
+		}
+
+		if len(transformers) > 0 {
+			transf = transform.Chain(transformers...)
+		}
+	}
+
+	return transf
+}
+
+// TestVSMSearchFromFile trains from a fixture file via the NDJSON
+// source, dogfooding vsm/source instead of hand-rolling a training
+// channel.
+func TestVSMSearchFromFile(t *testing.T) {
+	if !*fromFile {
+		t.Skip("Skipping tests loaded from file")
+	}
+
+	f, err := openTestFile(*fileName)
+	if err != nil {
+		t.Fatalf("got error loading test file: 'testdata/%s'.", *fileName)
+	}
+	defer f.Close()
+
+	var training fileTest
+
+	if err := json.NewDecoder(f).Decode(&training); err != nil {
+		t.Fatalf("got error parsing test file 'testdata/%s'.", *fileName)
+	}
+
+	var ndjson bytes.Buffer
+	enc := json.NewEncoder(&ndjson)
+	for _, doc := range training.Docs {
+		if err := enc.Encode(doc); err != nil {
+			t.Fatalf("got error encoding %+v as NDJSON: %q.", doc, err)
+		}
+	}
+
+	for _, tc := range training.Tests {
+		t.Run(tc.Query, func(t *testing.T) {
+			v := vsm.New(setupTransformer(training))
+
+			src := source.NewNDJSON(bytes.NewReader(ndjson.Bytes()))
+			resCh := v.TrainFromSource(context.Background(), src)
+
+			for res := range resCh {
+				if res.Err != nil {
+					t.Fatalf("Got error while training from source: %q.", res.Err)
+				}
+			}
+
+			matches, err := v.SearchN(tc.Query, len(tc.WantClasses), 0)
+			if err != nil {
+				t.Fatalf("Got error while searching for %q: %q.", tc.Query, err)
+			}
+
+			if len(matches) != len(tc.WantClasses) {
+				t.Fatalf("Got %d matches for %q; want %d.", len(matches), tc.Query, len(tc.WantClasses))
+			}
+
+			for i, want := range tc.WantClasses {
+				if got := matches[i].Class; got != want {
+					t.Errorf("Got %q class at rank %d; want %q.", got, i, want)
+				}
+			}
+		})
+	}
+}
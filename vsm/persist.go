@@ -0,0 +1,81 @@
+package vsm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"golang.org/x/text/transform"
+)
+
+// modelVersion is the schema version of the format written by Save and
+// understood by Load. Bump it whenever the persisted shape changes.
+const modelVersion = 1
+
+// model is the JSON-serializable snapshot of a VSM's trained state.
+// It carries everything needed to recompute IDF/TF-IDF vectors on load,
+// without re-running Train.
+type model struct {
+	Version   int              `json:"version"`
+	Docs      []Document       `json:"docs"`
+	TermFreqs []map[string]int `json:"termFreqs"`
+	DocFreq   map[string]int   `json:"docFreq"`
+}
+
+// Save writes a snapshot of the trained corpus to w as JSON, suitable
+// for reloading with Load so a corpus doesn't have to be re-trained on
+// every process start.
+func (v *VSM) Save(w io.Writer) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	m := model{
+		Version:   modelVersion,
+		Docs:      v.docs,
+		TermFreqs: v.termFreqs,
+		DocFreq:   v.docFreq,
+	}
+
+	return json.NewEncoder(w).Encode(m)
+}
+
+// Load reads a snapshot written by Save and returns a ready-to-query
+// VSM, using t as the transformer applied to future queries. It rejects
+// snapshots whose schema version it does not understand.
+func Load(r io.Reader, t transform.Transformer, opts ...Option) (*VSM, error) {
+	var m model
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("vsm: decoding model: %w", err)
+	}
+
+	if m.Version != modelVersion {
+		return nil, fmt.Errorf("vsm: unsupported model version %d, want %d", m.Version, modelVersion)
+	}
+
+	docFreq := m.DocFreq
+	if docFreq == nil {
+		docFreq = make(map[string]int)
+	}
+
+	var totalLen int
+	for _, tf := range m.TermFreqs {
+		for _, freq := range tf {
+			totalLen += freq
+		}
+	}
+
+	v := &VSM{
+		transformer: t,
+		scorer:      CosineScorer{},
+		docs:        m.Docs,
+		termFreqs:   m.TermFreqs,
+		docFreq:     docFreq,
+		totalLen:    totalLen,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
+}
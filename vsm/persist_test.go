@@ -0,0 +1,56 @@
+package vsm
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestVSMSaveLoad(t *testing.T) {
+	docs := docsFixture()
+
+	trained := New(nil)
+	setupTraining(t, trained, docs)
+
+	var buf bytes.Buffer
+	if err := trained.Save(&buf); err != nil {
+		t.Fatalf("Got error while saving: %q.", err)
+	}
+
+	loaded, err := Load(&buf, nil)
+	if err != nil {
+		t.Fatalf("Got error while loading: %q.", err)
+	}
+
+	queries := []string{
+		"gold silver truck.",
+		"shipment gold fire.",
+		"shipment gold in a flying truck.",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			want, err := trained.Search(query)
+			if err != nil {
+				t.Fatalf("Got error while searching trained VSM: %q.", err)
+			}
+
+			got, err := loaded.Search(query)
+			if err != nil {
+				t.Fatalf("Got error while searching loaded VSM: %q.", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Got %+v document; want %+v.", got, want)
+			}
+		})
+	}
+}
+
+func TestVSMLoadRejectsIncompatibleVersion(t *testing.T) {
+	buf := bytes.NewBufferString(`{"version":999,"docs":[],"termFreqs":[],"docFreq":{}}`)
+
+	if _, err := Load(buf, nil); err == nil {
+		t.Error("Got error nil loading incompatible version; want not nil.")
+	}
+}
@@ -0,0 +1,115 @@
+package vsm
+
+import "math"
+
+// CorpusStats carries the corpus-wide statistics a Scorer needs to
+// weigh a query against a document: the total number of documents, the
+// average document length in terms, and how many documents each term
+// appears in.
+type CorpusStats struct {
+	N         int
+	AvgDocLen float64
+	DocFreq   map[string]int
+}
+
+// Scorer ranks a document against a query. query and doc carry raw term
+// counts; implementations are responsible for any weighting (TF-IDF,
+// BM25, ...) using stats.
+type Scorer interface {
+	Score(query, doc TermVector, stats CorpusStats) float64
+}
+
+// CosineScorer scores documents by TF-IDF weighted cosine similarity.
+// It is the default Scorer used by New.
+type CosineScorer struct{}
+
+// Score implements Scorer.
+func (CosineScorer) Score(query, doc TermVector, stats CorpusStats) float64 {
+	return cosineSimilarity(tfidfWeight(query, stats), tfidfWeight(doc, stats))
+}
+
+// tfidfWeight multiplies each term's raw count in tf by its inverse
+// document frequency across the corpus described by stats.
+func tfidfWeight(tf TermVector, stats CorpusStats) TermVector {
+	vec := make(TermVector, len(tf))
+
+	for term, freq := range tf {
+		df := stats.DocFreq[term]
+		if df == 0 {
+			continue
+		}
+
+		idf := math.Log(float64(stats.N)/float64(df)) + 1
+		vec[term] = freq * idf
+	}
+
+	return vec
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b,
+// or 0 if either vector is zero-length.
+func cosineSimilarity(a, b TermVector) float64 {
+	var dot, na, nb float64
+
+	for term, wa := range a {
+		na += wa * wa
+
+		if wb, ok := b[term]; ok {
+			dot += wa * wb
+		}
+	}
+
+	for _, wb := range b {
+		nb += wb * wb
+	}
+
+	if na == 0 || nb == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// BM25Scorer scores documents with Okapi BM25. The zero value is not
+// usable, since K1: 0 and B: 0 are both legal BM25 parameters and so
+// can't double as "unset"; construct one with NewBM25Scorer.
+type BM25Scorer struct {
+	K1 float64
+	B  float64
+}
+
+// NewBM25Scorer returns a BM25Scorer configured with the standard
+// defaults (k1=1.2, b=0.75).
+func NewBM25Scorer() *BM25Scorer {
+	return &BM25Scorer{K1: 1.2, B: 0.75}
+}
+
+// Score implements Scorer.
+func (s *BM25Scorer) Score(query, doc TermVector, stats CorpusStats) float64 {
+	k1, b := s.K1, s.B
+
+	var docLen float64
+	for _, freq := range doc {
+		docLen += freq
+	}
+
+	var score float64
+	for term := range query {
+		df := stats.DocFreq[term]
+		if df == 0 {
+			continue
+		}
+
+		f := doc[term]
+		if f == 0 {
+			continue
+		}
+
+		idf := math.Log((float64(stats.N)-float64(df)+0.5)/(float64(df)+0.5) + 1)
+		denom := f + k1*(1-b+b*docLen/stats.AvgDocLen)
+
+		score += idf * (f * (k1 + 1)) / denom
+	}
+
+	return score
+}
@@ -0,0 +1,58 @@
+package vsm
+
+import "testing"
+
+func TestBM25Search(t *testing.T) {
+	docs := docsFixture()
+
+	testCases := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: "gold silver truck.",
+			want:  "d2",
+		},
+		{
+			query: "shipment gold fire.",
+			want:  "d1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			vsm := New(nil, WithScorer(NewBM25Scorer()))
+
+			setupTraining(t, vsm, docs)
+
+			got, err := vsm.Search(tc.query)
+			if err != nil {
+				t.Fatalf("Got error while searching for %q: %q.", tc.query, err)
+			}
+
+			if got == nil {
+				t.Fatalf("Got no document found for query: %q.", tc.query)
+			}
+
+			if got.Class != tc.want {
+				t.Errorf("Got %q class; want %q.", got.Class, tc.want)
+			}
+		})
+	}
+}
+
+// TestBM25ScorerZeroFields confirms K1: 0 and B: 0 are honored as the
+// explicit values they are, rather than being silently replaced with
+// NewBM25Scorer's defaults.
+func TestBM25ScorerZeroFields(t *testing.T) {
+	query := TermVector{"gold": 1}
+	doc := TermVector{"gold": 1}
+	stats := CorpusStats{N: 2, AvgDocLen: 4, DocFreq: map[string]int{"gold": 1}}
+
+	zero := (&BM25Scorer{K1: 0, B: 0}).Score(query, doc, stats)
+	defaults := NewBM25Scorer().Score(query, doc, stats)
+
+	if zero == defaults {
+		t.Fatalf("Got BM25Scorer{K1: 0, B: 0} score %v equal to the defaults %v; want K1/B: 0 honored as set.", zero, defaults)
+	}
+}
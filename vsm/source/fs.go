@@ -0,0 +1,65 @@
+package source
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/fossabot/go-vsm/vsm"
+)
+
+// FS streams one Document per regular file under Root, using the name
+// of the file's immediate parent directory as its Class. This suits
+// corpora laid out as one directory per class, one file per example.
+type FS struct {
+	Root string
+}
+
+// NewFS returns a Source that walks root.
+func NewFS(root string) *FS {
+	return &FS{Root: root}
+}
+
+// Documents implements Source.
+func (f *FS) Documents(ctx context.Context) (<-chan vsm.Document, <-chan error) {
+	docsCh := make(chan vsm.Document)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(docsCh)
+		defer close(errCh)
+
+		err := filepath.WalkDir(f.Root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			doc := vsm.Document{
+				Sentence: string(contents),
+				Class:    filepath.Base(filepath.Dir(path)),
+			}
+
+			select {
+			case docsCh <- doc:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return docsCh, errCh
+}
@@ -0,0 +1,50 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/fossabot/go-vsm/vsm"
+)
+
+// NDJSON streams Documents decoded from newline-delimited JSON objects
+// of the form {"sentence": "...", "class": "..."}, one per line.
+type NDJSON struct {
+	r io.Reader
+}
+
+// NewNDJSON returns a Source that decodes documents from r.
+func NewNDJSON(r io.Reader) *NDJSON {
+	return &NDJSON{r: r}
+}
+
+// Documents implements Source.
+func (n *NDJSON) Documents(ctx context.Context) (<-chan vsm.Document, <-chan error) {
+	docsCh := make(chan vsm.Document)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(docsCh)
+		defer close(errCh)
+
+		dec := json.NewDecoder(n.r)
+
+		for dec.More() {
+			var doc vsm.Document
+			if err := dec.Decode(&doc); err != nil {
+				errCh <- err
+				return
+			}
+
+			select {
+			case docsCh <- doc:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return docsCh, errCh
+}
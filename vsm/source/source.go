@@ -0,0 +1,17 @@
+// Package source provides Source implementations that stream
+// vsm.Documents from external stores, so a VSM can be trained from a
+// real corpus without hand-rolling channel plumbing.
+package source
+
+import (
+	"context"
+
+	"github.com/fossabot/go-vsm/vsm"
+)
+
+// Source streams Documents for training, reporting any read failures on
+// a separate error channel rather than mixing them into the Document
+// stream. Both channels are closed once the source is exhausted.
+type Source interface {
+	Documents(ctx context.Context) (<-chan vsm.Document, <-chan error)
+}
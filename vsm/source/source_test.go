@@ -0,0 +1,120 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fossabot/go-vsm/vsm"
+)
+
+// docsFixture returns the 2-document corpus ("gold"/"silver" shipments,
+// classes d1/d2) shared by the Source implementations' tests in this
+// package.
+func docsFixture() []vsm.Document {
+	return []vsm.Document{
+		{Sentence: "Shipment of gold damaged in a fire.", Class: "d1"},
+		{Sentence: "Delivery of silver arrived in a silver truck.", Class: "d2"},
+	}
+}
+
+func drain(t *testing.T, docsCh <-chan vsm.Document, errCh <-chan error) ([]vsm.Document, []error) {
+	t.Helper()
+
+	var docs []vsm.Document
+	var errs []error
+
+	for docsCh != nil || errCh != nil {
+		select {
+		case doc, ok := <-docsCh:
+			if !ok {
+				docsCh = nil
+				continue
+			}
+			docs = append(docs, doc)
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return docs, errs
+}
+
+func TestNDJSON(t *testing.T) {
+	r := strings.NewReader(`{"sentence":"Shipment of gold damaged in a fire.","class":"d1"}
+{"sentence":"Delivery of silver arrived in a silver truck.","class":"d2"}
+`)
+
+	docsCh, errCh := NewNDJSON(r).Documents(context.Background())
+	docs, errs := drain(t, docsCh, errCh)
+
+	if len(errs) != 0 {
+		t.Fatalf("Got errors %v; want none.", errs)
+	}
+
+	want := docsFixture()
+
+	if len(docs) != len(want) {
+		t.Fatalf("Got %d documents; want %d.", len(docs), len(want))
+	}
+
+	for i, w := range want {
+		if docs[i] != w {
+			t.Errorf("Got %+v at index %d; want %+v.", docs[i], i, w)
+		}
+	}
+}
+
+func TestNDJSONMalformed(t *testing.T) {
+	r := strings.NewReader(`not json`)
+
+	docsCh, errCh := NewNDJSON(r).Documents(context.Background())
+	_, errs := drain(t, docsCh, errCh)
+
+	if len(errs) != 1 {
+		t.Fatalf("Got %d errors; want 1.", len(errs))
+	}
+}
+
+func TestFS(t *testing.T) {
+	root := t.TempDir()
+
+	for _, doc := range docsFixture() {
+		dir := filepath.Join(root, doc.Class)
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatalf("Got error creating %q: %q.", dir, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "doc.txt"), []byte(doc.Sentence), 0o644); err != nil {
+			t.Fatalf("Got error writing fixture: %q.", err)
+		}
+	}
+
+	docsCh, errCh := NewFS(root).Documents(context.Background())
+	docs, errs := drain(t, docsCh, errCh)
+
+	if len(errs) != 0 {
+		t.Fatalf("Got errors %v; want none.", errs)
+	}
+
+	if len(docs) != 2 {
+		t.Fatalf("Got %d documents; want 2.", len(docs))
+	}
+
+	byClass := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		byClass[doc.Class] = doc.Sentence
+	}
+
+	for _, want := range docsFixture() {
+		if got := byClass[want.Class]; got != want.Sentence {
+			t.Errorf("Got sentence %q for class %q; want %q.", got, want.Class, want.Sentence)
+		}
+	}
+}
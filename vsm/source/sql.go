@@ -0,0 +1,54 @@
+package source
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/fossabot/go-vsm/vsm"
+)
+
+// SQLRows streams Documents from the result of a query selecting a
+// sentence column followed by a class column, e.g.
+// "SELECT sentence, class FROM examples". Documents takes ownership of
+// rows and closes it once exhausted.
+type SQLRows struct {
+	rows *sql.Rows
+}
+
+// NewSQLRows returns a Source backed by rows.
+func NewSQLRows(rows *sql.Rows) *SQLRows {
+	return &SQLRows{rows: rows}
+}
+
+// Documents implements Source.
+func (s *SQLRows) Documents(ctx context.Context) (<-chan vsm.Document, <-chan error) {
+	docsCh := make(chan vsm.Document)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(docsCh)
+		defer close(errCh)
+		defer s.rows.Close()
+
+		for s.rows.Next() {
+			var doc vsm.Document
+			if err := s.rows.Scan(&doc.Sentence, &doc.Class); err != nil {
+				errCh <- err
+				return
+			}
+
+			select {
+			case docsCh <- doc:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+
+		if err := s.rows.Err(); err != nil {
+			errCh <- err
+		}
+	}()
+
+	return docsCh, errCh
+}
@@ -0,0 +1,163 @@
+package source
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeDriverSeq makes each openFakeRows call register its driver under a
+// name no other call (in this run or a repeat -count run) has used, since
+// sql.Register panics on a name collision.
+var fakeDriverSeq int64
+
+// fakeRow is one row a fakeDriver hands back from a query.
+type fakeRow struct {
+	sentence, class string
+}
+
+// fakeDriver is a minimal database/sql/driver.Driver backing a single
+// canned result set, so SQLRows can be exercised without a real
+// database.
+type fakeDriver struct {
+	rows   []fakeRow
+	rowErr error // returned by Next once rows are exhausted, instead of io.EOF
+	closed bool
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakeConn: Prepare not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fakeConn: Begin not supported")
+}
+
+func (c *fakeConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{driver: c.driver}, nil
+}
+
+type fakeRows struct {
+	driver *fakeDriver
+	idx    int
+}
+
+func (r *fakeRows) Columns() []string { return []string{"sentence", "class"} }
+
+func (r *fakeRows) Close() error {
+	r.driver.closed = true
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.driver.rows) {
+		if r.driver.rowErr != nil {
+			return r.driver.rowErr
+		}
+		return io.EOF
+	}
+
+	row := r.driver.rows[r.idx]
+	dest[0] = row.sentence
+	dest[1] = row.class
+	r.idx++
+
+	return nil
+}
+
+// openFakeRows registers d under a name unique to this call and returns
+// the *sql.Rows from querying it.
+func openFakeRows(t *testing.T, d *fakeDriver) *sql.Rows {
+	t.Helper()
+
+	name := fmt.Sprintf("fakevsm-%s-%d", t.Name(), atomic.AddInt64(&fakeDriverSeq, 1))
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("Got error opening fake db: %q.", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	rows, err := db.QueryContext(context.Background(), "SELECT sentence, class FROM examples")
+	if err != nil {
+		t.Fatalf("Got error querying fake db: %q.", err)
+	}
+
+	return rows
+}
+
+func TestSQLRows(t *testing.T) {
+	want := docsFixture()
+
+	rows := make([]fakeRow, len(want))
+	for i, doc := range want {
+		rows[i] = fakeRow{sentence: doc.Sentence, class: doc.Class}
+	}
+
+	d := &fakeDriver{rows: rows}
+
+	docsCh, errCh := NewSQLRows(openFakeRows(t, d)).Documents(context.Background())
+	docs, errs := drain(t, docsCh, errCh)
+
+	if len(errs) != 0 {
+		t.Fatalf("Got errors %v; want none.", errs)
+	}
+
+	if len(docs) != len(want) {
+		t.Fatalf("Got %d documents; want %d.", len(docs), len(want))
+	}
+
+	for i, w := range want {
+		if docs[i] != w {
+			t.Errorf("Got %+v at index %d; want %+v.", docs[i], i, w)
+		}
+	}
+
+	if !d.closed {
+		t.Error("Got rows not closed; want Close to have run.")
+	}
+}
+
+func TestSQLRowsErr(t *testing.T) {
+	wantErr := errors.New("connection lost mid-scan")
+	first := docsFixture()[0]
+	d := &fakeDriver{
+		rows:   []fakeRow{{sentence: first.Sentence, class: first.Class}},
+		rowErr: wantErr,
+	}
+
+	docsCh, errCh := NewSQLRows(openFakeRows(t, d)).Documents(context.Background())
+	docs, errs := drain(t, docsCh, errCh)
+
+	if len(docs) != 1 {
+		t.Fatalf("Got %d documents; want 1.", len(docs))
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("Got %d errors; want 1.", len(errs))
+	}
+
+	if !errors.Is(errs[0], wantErr) {
+		t.Errorf("Got error %q; want it to wrap %q.", errs[0], wantErr)
+	}
+
+	if !d.closed {
+		t.Error("Got rows not closed; want Close to have run.")
+	}
+}
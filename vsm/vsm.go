@@ -0,0 +1,282 @@
+// Package vsm implements a simple Vector Space Model for classifying
+// sentences against a trained corpus of documents, ranking matches with
+// a pluggable Scorer (TF-IDF weighted cosine similarity by default).
+package vsm
+
+import (
+	"container/heap"
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/transform"
+)
+
+// Document is a single piece of training data: a sentence and the class
+// it belongs to.
+type Document struct {
+	Sentence string `json:"sentence"`
+	Class    string `json:"class"`
+}
+
+// ScoredDocument pairs a Document with the similarity score it obtained
+// against a query.
+type ScoredDocument struct {
+	Document
+	Score float64
+}
+
+// TermVector maps a term to its weight within a document or query.
+type TermVector map[string]float64
+
+// TrainResult is sent on the channel returned by Train for every
+// Document consumed, carrying any error encountered while indexing it.
+type TrainResult struct {
+	Doc Document
+	Err error
+}
+
+// VSM is a trained vector space model. The zero value is not usable;
+// construct one with New.
+type VSM struct {
+	mu          sync.RWMutex
+	transformer transform.Transformer
+	scorer      Scorer
+
+	docs      []Document
+	termFreqs []map[string]int
+	docFreq   map[string]int
+	totalLen  int
+}
+
+// Option configures a VSM constructed with New.
+type Option func(*VSM)
+
+// WithScorer overrides the Scorer used to rank documents against a
+// query. The default is TF-IDF weighted cosine similarity.
+func WithScorer(s Scorer) Option {
+	return func(v *VSM) {
+		v.scorer = s
+	}
+}
+
+// New creates an empty VSM. If t is non-nil, it is applied to every
+// sentence (both training documents and queries) before tokenizing.
+func New(t transform.Transformer, opts ...Option) *VSM {
+	v := &VSM{
+		transformer: t,
+		scorer:      CosineScorer{},
+		docFreq:     make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Train consumes documents from docs, indexing each one as it arrives,
+// and reports the outcome of every document (or a context error) on the
+// returned channel. The channel is closed once docs is closed or ctx is
+// done.
+func (v *VSM) Train(ctx context.Context, docs <-chan Document) <-chan TrainResult {
+	resCh := make(chan TrainResult)
+
+	go func() {
+		defer close(resCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				resCh <- TrainResult{Err: ctx.Err()}
+				return
+			case doc, ok := <-docs:
+				if !ok {
+					return
+				}
+
+				ie := v.indexDoc(doc)
+				resCh <- TrainResult{Doc: ie.Doc, Err: ie.Err}
+			}
+		}
+	}()
+
+	return resCh
+}
+
+// tokenize lowercases sentence and splits it into words, trimming
+// leading and trailing punctuation from each one. Hyphens inside a word
+// are kept, so compound words stay a single term unless a transformer
+// has already split them apart.
+func tokenize(sentence string) []string {
+	fields := strings.Fields(strings.ToLower(sentence))
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		term := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+		})
+
+		if term != "" {
+			tokens = append(tokens, term)
+		}
+	}
+
+	return tokens
+}
+
+// index tokenizes doc.Sentence (after applying the configured
+// transformer, if any) and folds it into the corpus statistics.
+func (v *VSM) index(doc Document) error {
+	tf, err := v.termFrequencies(doc.Sentence)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.docs = append(v.docs, doc)
+	v.termFreqs = append(v.termFreqs, tf)
+
+	for term, freq := range tf {
+		v.docFreq[term]++
+		v.totalLen += freq
+	}
+
+	return nil
+}
+
+// indexDoc indexes doc and reports the outcome as an IndexError, the
+// shared "index one doc, turn a failure into the right result type"
+// step used by both Train and BulkIndexer's flush path.
+func (v *VSM) indexDoc(doc Document) IndexError {
+	return IndexError{Doc: doc, Err: v.index(doc)}
+}
+
+// termFrequencies applies the configured transformer to sentence and
+// returns a map of term to occurrence count.
+func (v *VSM) termFrequencies(sentence string) (map[string]int, error) {
+	if v.transformer != nil {
+		v.transformer.Reset()
+
+		transformed, _, err := transform.String(v.transformer, sentence)
+		if err != nil {
+			return nil, err
+		}
+
+		sentence = transformed
+	}
+
+	tokens := tokenize(sentence)
+
+	tf := make(map[string]int, len(tokens))
+	for _, tok := range tokens {
+		tf[tok]++
+	}
+
+	return tf, nil
+}
+
+// termVector converts raw term counts into a TermVector.
+func termVector(tf map[string]int) TermVector {
+	vec := make(TermVector, len(tf))
+	for term, freq := range tf {
+		vec[term] = float64(freq)
+	}
+
+	return vec
+}
+
+// Search returns the Document in the corpus most similar to query, or
+// nil if the corpus is empty or no document scores above zero.
+func (v *VSM) Search(query string) (*Document, error) {
+	matches, err := v.SearchN(query, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	return &matches[0].Document, nil
+}
+
+// SearchN returns up to k documents most similar to query, ranked by
+// descending similarity score, dropping any match whose score is not
+// strictly greater than threshold. It runs in O(N log k) using a
+// bounded min-heap rather than sorting the whole corpus.
+func (v *VSM) SearchN(query string, k int, threshold float64) ([]ScoredDocument, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if k <= 0 {
+		return nil, nil
+	}
+
+	qtf, err := v.termFrequencies(query)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(v.docs)
+	if n == 0 {
+		return nil, nil
+	}
+
+	stats := CorpusStats{
+		N:         n,
+		AvgDocLen: float64(v.totalLen) / float64(n),
+		DocFreq:   v.docFreq,
+	}
+
+	qvec := termVector(qtf)
+
+	top := make(scoredHeap, 0, k)
+
+	for i, doc := range v.docs {
+		dvec := termVector(v.termFreqs[i])
+		score := v.scorer.Score(qvec, dvec, stats)
+
+		if score <= threshold {
+			continue
+		}
+
+		if len(top) < k {
+			heap.Push(&top, ScoredDocument{Document: doc, Score: score})
+			continue
+		}
+
+		if score > top[0].Score {
+			top[0] = ScoredDocument{Document: doc, Score: score}
+			heap.Fix(&top, 0)
+		}
+	}
+
+	results := make([]ScoredDocument, len(top))
+	for i := len(top) - 1; i >= 0; i-- {
+		results[i] = heap.Pop(&top).(ScoredDocument)
+	}
+
+	return results, nil
+}
+
+// scoredHeap is a min-heap of ScoredDocument ordered by ascending Score,
+// used by SearchN to keep only the top-k matches without sorting the
+// whole corpus.
+type scoredHeap []ScoredDocument
+
+func (h scoredHeap) Len() int            { return len(h) }
+func (h scoredHeap) Less(i, j int) bool  { return h[i].Score < h[j].Score }
+func (h scoredHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *scoredHeap) Push(x interface{}) { *h = append(*h, x.(ScoredDocument)) }
+func (h *scoredHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
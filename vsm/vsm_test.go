@@ -2,13 +2,8 @@ package vsm
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"flag"
-	"fmt"
-	"os"
 	"reflect"
-	"strings"
 	"testing"
 	"time"
 	"unicode"
@@ -17,33 +12,24 @@ import (
 	"golang.org/x/text/transform"
 )
 
-var fromFile = flag.Bool("fromfile", false, `test from files inside "testdata" dir.`)
-var fileName = flag.String("filename", "training.json", "name of the file that contains the tests.")
-
-type fileTest struct {
-	Docs      []Document `json:"documents"`
-	Transform *struct {
-		Map *struct {
-			Runes string `json:"runes"`
-			To    string `json:"to"`
-		} `json:"map"`
-	} `json:"transform"`
-	Tests []struct {
-		Query string `json:"query"`
-		Want  string `json:"wantClass"`
-	} `json:"tests"`
-}
-
-// openTestFile reads a file from `name` and returns a file descriptor.
-// The file should be in `testdata` dir, as the `name` will be prefixed
-// with `testdata/`. It the caller's responsability to close the file when needed.
-func openTestFile(name string) (*os.File, error) {
-	file, err := os.Open(fmt.Sprintf("testdata%s%s", string(os.PathSeparator), name))
-	if err != nil {
-		return nil, err
+// docsFixture returns the 3-document corpus ("gold"/"silver" shipments,
+// classes d1-d3) shared by the tests in this package that need a small
+// trained VSM to search against.
+func docsFixture() []Document {
+	return []Document{
+		{
+			Sentence: "Shipment of gold damaged in a fire.",
+			Class:    "d1",
+		},
+		{
+			Sentence: "Delivery of silver arrived in a silver truck.",
+			Class:    "d2",
+		},
+		{
+			Sentence: "Shipment-of-gold-arrived in a truck.",
+			Class:    "d3",
+		},
 	}
-
-	return file, nil
 }
 
 func setupTraining(t *testing.T, vsm *VSM, docs []Document) {
@@ -68,83 +54,8 @@ func setupTraining(t *testing.T, vsm *VSM, docs []Document) {
 	}
 }
 
-func setupTransformer(training fileTest) transform.Transformer {
-	var transf transform.Transformer
-	if training.Transform != nil {
-		var transformers []transform.Transformer
-
-		if m := training.Transform.Map; m != nil {
-			newRune := []rune(m.To)
-			transformers = append(transformers, runes.Map(func(r rune) rune {
-				if strings.ContainsRune(m.Runes, r) && len(newRune) > 0 {
-					return newRune[0]
-				}
-				return r
-			}))
-		}
-
-		if len(transformers) > 0 {
-			transf = transform.Chain(transformers...)
-		}
-	}
-
-	return transf
-}
-
-func TestVSMSearchFromFile(t *testing.T) {
-	if !*fromFile {
-		t.Skip("Skipping tests loaded from file")
-	}
-
-	f, err := openTestFile(*fileName)
-	if err != nil {
-		t.Fatalf("got error loading test file: 'testdata/%s'.", *fileName)
-	}
-	defer f.Close()
-
-	var training fileTest
-
-	if err := json.NewDecoder(f).Decode(&training); err != nil {
-		t.Fatalf("got error parsing test file 'testdata/%s'.", *fileName)
-	}
-
-	for _, tc := range training.Tests {
-		t.Run(tc.Query, func(t *testing.T) {
-			vsm := New(setupTransformer(training))
-
-			setupTraining(t, vsm, training.Docs)
-
-			doc, err := vsm.Search(tc.Query)
-			if err != nil {
-				t.Fatalf("Got error while searching for %q: %q.", tc.Query, err)
-			}
-
-			if doc == nil {
-				t.Fatalf("Got no document found for query: %q.", tc.Query)
-			}
-
-			if got := doc.Class; got != tc.Want {
-				t.Errorf("Got %q class; want %q.", got, tc.Want)
-			}
-		})
-	}
-}
-
 func TestClassificationSearch(t *testing.T) {
-	docs := []Document{
-		Document{
-			Sentence: "Shipment of gold damaged in a fire.",
-			Class:    "d1",
-		},
-		Document{
-			Sentence: "Delivery of silver arrived in a silver truck.",
-			Class:    "d2",
-		},
-		Document{
-			Sentence: "Shipment-of-gold-arrived in a truck.",
-			Class:    "d3",
-		},
-	}
+	docs := docsFixture()
 
 	testCases := []struct {
 		transformer transform.Transformer
@@ -196,6 +107,49 @@ func TestClassificationSearch(t *testing.T) {
 	}
 }
 
+func TestVSMSearchN(t *testing.T) {
+	docs := docsFixture()
+
+	vsm := New(nil)
+
+	setupTraining(t, vsm, docs)
+
+	t.Run("ranked top-2", func(t *testing.T) {
+		matches, err := vsm.SearchN("gold silver truck.", 2, 0)
+		if err != nil {
+			t.Fatalf("Got error while searching: %q.", err)
+		}
+
+		wantClasses := []string{"d2", "d1"}
+		if len(matches) != len(wantClasses) {
+			t.Fatalf("Got %d matches; want %d.", len(matches), len(wantClasses))
+		}
+
+		for i, want := range wantClasses {
+			if got := matches[i].Class; got != want {
+				t.Errorf("Got %q class at rank %d; want %q.", got, i, want)
+			}
+		}
+
+		for i := 1; i < len(matches); i++ {
+			if matches[i].Score > matches[i-1].Score {
+				t.Errorf("Got matches out of order: %+v", matches)
+			}
+		}
+	})
+
+	t.Run("threshold drops weak matches", func(t *testing.T) {
+		matches, err := vsm.SearchN("this query should result an empty document.", 3, 0)
+		if err != nil {
+			t.Fatalf("Got error while searching: %q.", err)
+		}
+
+		if len(matches) != 0 {
+			t.Errorf("Got %d matches; want 0.", len(matches))
+		}
+	})
+}
+
 type testingTransformer struct {
 	nDst, nSrc int
 	err        error